@@ -0,0 +1,120 @@
+package gobcrypt
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2Version is the argon2 algorithm version this package encodes into
+// the "v=" field, matching golang.org/x/crypto/argon2's implementation.
+const argon2Version = 19
+
+// Argon2idHasher hashes passwords with argon2id, encoding hashes in the
+// standard PHC string format:
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>
+type Argon2idHasher struct {
+	// Time is the number of iterations (argon2 "t" parameter).
+	Time uint32
+	// Memory is the memory cost in KiB (argon2 "m" parameter).
+	Memory uint32
+	// Threads is the degree of parallelism (argon2 "p" parameter).
+	Threads uint8
+	// SaltLen and KeyLen control the generated salt and digest lengths, in
+	// bytes.
+	SaltLen uint32
+	KeyLen  uint32
+}
+
+// NewArgon2idHasher returns an Argon2idHasher with sensible defaults for
+// any of time, memory, or threads left at zero (64 MiB memory, 3
+// iterations, 2 threads), and a 16-byte salt / 32-byte key.
+func NewArgon2idHasher(time, memory uint32, threads uint8) *Argon2idHasher {
+	if time == 0 {
+		time = 3
+	}
+	if memory == 0 {
+		memory = 64 * 1024
+	}
+	if threads == 0 {
+		threads = 2
+	}
+	return &Argon2idHasher{Time: time, Memory: memory, Threads: threads, SaltLen: 16, KeyLen: 32}
+}
+
+// Generate implements Hasher.
+func (h *Argon2idHasher) Generate(password []byte) ([]byte, error) {
+	salt := make([]byte, h.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGenerateFailed, err)
+	}
+	key := argon2.IDKey(password, salt, h.Time, h.Memory, h.Threads, h.KeyLen)
+
+	encoded := fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2Version, h.Memory, h.Time, h.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+	return []byte(encoded), nil
+}
+
+// Compare implements Hasher.
+func (h *Argon2idHasher) Compare(hash, password []byte) error {
+	_, memory, time, threads, salt, key, err := parseArgon2idHash(hash)
+	if err != nil {
+		return err
+	}
+	candidate := argon2.IDKey(password, salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		return ErrCompareFailed
+	}
+	return nil
+}
+
+// Cost implements Hasher, returning the iteration count (the "t" parameter).
+func (h *Argon2idHasher) Cost(hash []byte) (int, error) {
+	_, _, time, _, _, _, err := parseArgon2idHash(hash)
+	if err != nil {
+		return 0, err
+	}
+	return int(time), nil
+}
+
+// NeedsRehash implements Hasher, reporting true if hash used weaker memory,
+// time, or parallelism parameters than h is currently configured with.
+func (h *Argon2idHasher) NeedsRehash(hash []byte) bool {
+	_, memory, time, threads, _, _, err := parseArgon2idHash(hash)
+	if err != nil {
+		return true
+	}
+	return memory < h.Memory || time < h.Time || threads < h.Threads
+}
+
+// parseArgon2idHash decodes a $argon2id$v=...$m=...,t=...,p=...$salt$hash
+// string into its parameters, salt, and digest.
+func parseArgon2idHash(hash []byte) (version int, memory, time uint32, threads uint8, salt, key []byte, err error) {
+	parts := strings.Split(string(hash), "$")
+	// Splitting on '$' yields 6 parts: an empty string before the leading
+	// '$', then variant, version, params, salt, and digest.
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return 0, 0, 0, 0, nil, nil, ErrInvalidHash
+	}
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return 0, 0, 0, 0, nil, nil, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+	var p uint32
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &p); err != nil {
+		return 0, 0, 0, 0, nil, nil, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+	threads = uint8(p)
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return 0, 0, 0, 0, nil, nil, fmt.Errorf("%w: invalid salt: %v", ErrInvalidHash, err)
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return 0, 0, 0, 0, nil, nil, fmt.Errorf("%w: invalid digest: %v", ErrInvalidHash, err)
+	}
+	return version, memory, time, threads, salt, key, nil
+}
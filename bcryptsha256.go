@@ -0,0 +1,86 @@
+package gobcrypt
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// bcryptSHA256Prefix marks a hash as produced by BcryptSHA256Hasher rather
+// than plain bcrypt, so Registry/Verify can tell the two apart even though
+// the wrapped hash is itself a normal bcrypt hash.
+const bcryptSHA256Prefix = "$bcrypt-sha256$"
+
+// BcryptSHA256Hasher HMACs the password with SHA-256 (keyed with an empty
+// key) before bcrypt, eliminating bcrypt's 72-byte truncation issue without
+// the ambiguity of this package's plain SHA-256 pre-hash, which only
+// applies above PasswordLimit and so changes behavior at a length
+// boundary. Every password is pre-hashed here, long or short.
+type BcryptSHA256Hasher struct {
+	// TargetCost is the cost new hashes are generated with, and the minimum cost
+	// NeedsRehash accepts without flagging a hash for upgrade.
+	TargetCost int
+}
+
+// NewBcryptSHA256Hasher returns a BcryptSHA256Hasher that generates hashes
+// at cost.
+func NewBcryptSHA256Hasher(cost int) *BcryptSHA256Hasher {
+	return &BcryptSHA256Hasher{TargetCost: cost}
+}
+
+// prehash HMAC-SHA256s password with an empty key and base64-encodes the
+// result, producing a fixed-length input for bcrypt.
+func (h *BcryptSHA256Hasher) prehash(password []byte) []byte {
+	mac := hmac.New(sha256.New, nil)
+	mac.Write(password)
+	sum := mac.Sum(nil)
+	encoded := make([]byte, stdEncodedLen)
+	base64.StdEncoding.Encode(encoded, sum)
+	return encoded
+}
+
+// strip removes the bcryptSHA256Prefix wrapper, returning the inner bcrypt
+// hash.
+func (h *BcryptSHA256Hasher) strip(hash []byte) ([]byte, error) {
+	if !bytes.HasPrefix(hash, []byte(bcryptSHA256Prefix)) {
+		return nil, ErrInvalidHash
+	}
+	return hash[len(bcryptSHA256Prefix):], nil
+}
+
+// Generate implements Hasher.
+func (h *BcryptSHA256Hasher) Generate(password []byte) ([]byte, error) {
+	inner, err := Generate(h.prehash(password), h.TargetCost)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(bcryptSHA256Prefix), inner...), nil
+}
+
+// Compare implements Hasher.
+func (h *BcryptSHA256Hasher) Compare(hash, password []byte) error {
+	inner, err := h.strip(hash)
+	if err != nil {
+		return err
+	}
+	return Compare(inner, h.prehash(password))
+}
+
+// Cost implements Hasher.
+func (h *BcryptSHA256Hasher) Cost(hash []byte) (int, error) {
+	inner, err := h.strip(hash)
+	if err != nil {
+		return 0, err
+	}
+	return Cost(inner)
+}
+
+// NeedsRehash implements Hasher.
+func (h *BcryptSHA256Hasher) NeedsRehash(hash []byte) bool {
+	inner, err := h.strip(hash)
+	if err != nil {
+		return true
+	}
+	return NeedsRehash(inner, h.TargetCost)
+}
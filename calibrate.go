@@ -0,0 +1,171 @@
+package gobcrypt
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// calibrationPassword is the throwaway input hashed while measuring cost
+// timings. Its content is irrelevant; only the timing of Generate matters.
+var calibrationPassword = []byte("gobcrypt-calibration-password")
+
+var (
+	calibrateOnce  sync.Once
+	calibratedCost int
+	calibrateErr   error
+)
+
+// Calibrate measures how long Generate takes on the current machine and
+// returns the highest cost whose hashing time stays within target.
+//
+// It hashes calibrationPassword at minCost to get a baseline, then walks
+// the cost upward one level at a time, using the fact that each increment
+// roughly doubles the previous level's duration to decide, before actually
+// measuring it, whether the next level would still fit under target. This
+// verifies the projection one step at a time instead of jumping straight to
+// a projected cost and measuring there, which could mean a single Generate
+// call taking far longer than target if the projection is optimistic. The
+// result is clamped to [MinCost, MaxCost] (further clamped to [minCost,
+// maxCost] if those are narrower).
+//
+// Parameters:
+//   - target: The maximum acceptable duration for Generate to run.
+//   - minCost: The lowest cost to consider; values below MinCost are raised to it.
+//   - maxCost: The highest cost to consider; values above MaxCost are lowered to it.
+//
+// Returns:
+//   - int: The highest cost that hashed calibrationPassword within target,
+//     or the (clamped) minCost if even that cost exceeds target.
+//   - error: An error if minCost/maxCost are invalid or Generate fails.
+func Calibrate(target time.Duration, minCost, maxCost int) (int, error) {
+	return calibrate(context.Background(), target, minCost, maxCost)
+}
+
+// CalibrateContext is like Calibrate but checks ctx before each measurement,
+// returning ctx.Err() if it's cancelled before calibration completes. Use
+// this to bound how long calibration can block a caller (e.g. request
+// handling) on startup.
+func CalibrateContext(ctx context.Context, target time.Duration, minCost, maxCost int) (int, error) {
+	return calibrate(ctx, target, minCost, maxCost)
+}
+
+func calibrate(ctx context.Context, target time.Duration, minCost, maxCost int) (int, error) {
+	if minCost < MinCost {
+		minCost = MinCost
+	}
+	if maxCost > MaxCost {
+		maxCost = MaxCost
+	}
+	if minCost > maxCost {
+		return 0, ErrInvalidCalibrationRange
+	}
+
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	cost := minCost
+	elapsed, err := timeGenerate(cost)
+	if err != nil {
+		return 0, err
+	}
+
+	// Step up one cost level at a time. Before measuring the next level,
+	// check whether the previous level's duration (which roughly halves
+	// each time cost drops by one) already projects past target, so we
+	// never run Generate at a cost far beyond what target allows.
+	for cost < maxCost && elapsed*2 <= target {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		cost++
+		elapsed, err = timeGenerate(cost)
+		if err != nil {
+			return 0, err
+		}
+		if elapsed > target {
+			cost--
+			break
+		}
+	}
+
+	return cost, nil
+}
+
+// calibrationSamples is how many times timeGenerate hashes calibrationPassword
+// at a given cost before taking the median, so a single slow tick (GC,
+// scheduling) can't throw off the measurement at that level.
+const calibrationSamples = 3
+
+// timeGenerate hashes calibrationPassword at cost calibrationSamples times
+// and returns the median duration, to stay resistant to scheduling noise and
+// GC pauses that could otherwise make a single sample unrepresentative.
+func timeGenerate(cost int) (time.Duration, error) {
+	samples := make([]time.Duration, calibrationSamples)
+	for i := range samples {
+		start := time.Now()
+		if _, err := Generate(calibrationPassword, cost); err != nil {
+			return 0, err
+		}
+		samples[i] = time.Since(start)
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	return samples[len(samples)/2], nil
+}
+
+// GenerateAuto hashes password at a cost calibrated to keep Generate's
+// running time within target on the current machine. The calibration runs
+// at most once per process (guarded by a sync.Once) so repeated calls, such
+// as one per login, don't pay the calibration cost again; call Calibrate
+// directly if you need to recalibrate.
+//
+// Parameters:
+//   - password: The plaintext password to hash.
+//   - target: The maximum acceptable duration for hashing.
+//
+// Returns:
+//   - []byte: The generated bcrypt hash.
+//   - error: An error if calibration or generation fails.
+func GenerateAuto(password []byte, target time.Duration) ([]byte, error) {
+	calibrateOnce.Do(func() {
+		calibratedCost, calibrateErr = Calibrate(target, MinCost, MaxCost)
+	})
+	if calibrateErr != nil {
+		return nil, calibrateErr
+	}
+	return Generate(password, calibratedCost)
+}
+
+// MustCalibrate is like Calibrate but panics instead of returning an error.
+// It's meant for use at program startup (e.g. to initialize a package-level
+// cost), where an invalid range or a failing Generate call indicates a
+// misconfigured deployment that should fail fast.
+func MustCalibrate(target time.Duration, minCost, maxCost int) int {
+	cost, err := Calibrate(target, minCost, maxCost)
+	if err != nil {
+		panic(err)
+	}
+	return cost
+}
+
+// recommendedCost caches the cost Calibrate(250*time.Millisecond, MinCost,
+// MaxCost) settles on for the current machine, computed once via a
+// sync.Once-guarded calibration the first time RecommendedCost is called.
+// Services that
+// don't want to choose a target duration themselves can use this as the
+// cost passed to Generate after hardware changes, instead of hard-coding
+// DefaultCost.
+var (
+	recommendedCostOnce sync.Once
+	recommendedCost     int
+)
+
+// RecommendedCost returns the process-wide calibrated cost, computing it on
+// first call and caching it for the life of the process.
+func RecommendedCost() int {
+	recommendedCostOnce.Do(func() {
+		recommendedCost = MustCalibrate(250*time.Millisecond, MinCost, MaxCost)
+	})
+	return recommendedCost
+}
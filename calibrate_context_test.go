@@ -0,0 +1,44 @@
+package gobcrypt
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMustCalibrate(t *testing.T) {
+	cost := MustCalibrate(2*time.Second, MinCost, MinCost+2)
+	if cost < MinCost || cost > MinCost+2 {
+		t.Errorf("expected cost in [%d, %d], got %d", MinCost, MinCost+2, cost)
+	}
+}
+
+func TestCalibrateContext(t *testing.T) {
+	t.Run("AlreadyCancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := CalibrateContext(ctx, time.Second, MinCost, MinCost+2); err == nil {
+			t.Error("expected error for already-cancelled context, got nil")
+		}
+	})
+
+	t.Run("NotCancelled", func(t *testing.T) {
+		cost, err := CalibrateContext(context.Background(), 2*time.Second, MinCost, MinCost+2)
+		if err != nil {
+			t.Fatalf("CalibrateContext failed: %v", err)
+		}
+		if cost < MinCost || cost > MinCost+2 {
+			t.Errorf("expected cost in [%d, %d], got %d", MinCost, MinCost+2, cost)
+		}
+	})
+}
+
+func TestRecommendedCost(t *testing.T) {
+	cost := RecommendedCost()
+	if cost < MinCost || cost > MaxCost {
+		t.Errorf("expected cost in [%d, %d], got %d", MinCost, MaxCost, cost)
+	}
+	if RecommendedCost() != cost {
+		t.Error("expected RecommendedCost to be cached after the first call")
+	}
+}
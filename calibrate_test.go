@@ -0,0 +1,37 @@
+package gobcrypt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalibrate(t *testing.T) {
+	t.Run("ClampsToMinCost", func(t *testing.T) {
+		// A target far too small for even MinCost should still return a
+		// usable cost rather than erroring or dropping below MinCost.
+		cost, err := Calibrate(time.Nanosecond, MinCost, MinCost+2)
+		if err != nil {
+			t.Fatalf("Calibrate failed: %v", err)
+		}
+		if cost < MinCost || cost > MinCost+2 {
+			t.Errorf("expected cost in [%d, %d], got %d", MinCost, MinCost+2, cost)
+		}
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		_, err := Calibrate(time.Second, MinCost+2, MinCost)
+		if err == nil {
+			t.Error("expected error for minCost > maxCost, got nil")
+		}
+	})
+}
+
+func TestGenerateAuto(t *testing.T) {
+	hash, err := GenerateAuto([]byte("password123"), 2*time.Second)
+	if err != nil {
+		t.Fatalf("GenerateAuto failed: %v", err)
+	}
+	if err := Compare(hash, []byte("password123")); err != nil {
+		t.Errorf("expected GenerateAuto hash to verify, got %v", err)
+	}
+}
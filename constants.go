@@ -39,3 +39,19 @@ var ErrCompareFailed = errors.New("gobcrypt: password comparison failed")
 
 // ErrInvalidHash is returned when the hash is invalid.
 var ErrInvalidHash = errors.New("gobcrypt: invalid hash")
+
+// ErrInvalidCalibrationRange is returned when Calibrate is given a minCost
+// greater than maxCost.
+var ErrInvalidCalibrationRange = errors.New("gobcrypt: minCost must not exceed maxCost")
+
+// ErrUnknownHashVariant is returned when a hash's format prefix does not
+// match any backend a Hasher (or Registry) is configured to handle.
+var ErrUnknownHashVariant = errors.New("gobcrypt: unknown hash variant")
+
+// ErrInvalidSaltLength is returned when GenerateWithSalt is given a salt
+// that is not exactly 16 bytes.
+var ErrInvalidSaltLength = errors.New("gobcrypt: salt must be exactly 16 bytes")
+
+// ErrPoolBusy is returned by Pool.Generate and Pool.Compare when the pool's
+// work queue is full.
+var ErrPoolBusy = errors.New("gobcrypt: pool queue is full")
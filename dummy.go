@@ -0,0 +1,72 @@
+package gobcrypt
+
+import "sync"
+
+// dummyPassword is hashed to build the fallback comparison target used by
+// CompareOrDummy when no real hash exists. Its content is irrelevant.
+var dummyPassword = []byte("gobcrypt-dummy-password-for-timing-safety")
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// SetDummyHash overrides the hash CompareOrDummy compares against when the
+// user does not exist, letting a service preload one generated at its own
+// production cost instead of paying for lazy generation on the first miss.
+// Called before the first miss, it also skips the lazy generation; called
+// later, it replaces the hash generated so far. It writes the cached hash
+// without locking, so it must not run concurrently with CompareOrDummy.
+func SetDummyHash(hash []byte) {
+	dummyHashOnce.Do(func() {})
+	dummyHash = append([]byte(nil), hash...)
+}
+
+// getDummyHash lazily generates the dummy hash at cost, once per process.
+func getDummyHash(cost int) []byte {
+	dummyHashOnce.Do(func() {
+		h, err := Generate(dummyPassword, cost)
+		if err != nil {
+			// cost has already been validated by the caller's production
+			// hashing; fall back to MinCost on the off chance it hasn't.
+			h, _ = Generate(dummyPassword, MinCost)
+		}
+		dummyHash = h
+	})
+	return dummyHash
+}
+
+// CompareOrDummy behaves like Compare, except when hash is empty or is not
+// recognized by any backend in DefaultRegistry (i.e. the account does not
+// exist): instead of returning immediately, it still performs a full bcrypt
+// comparison against a cached dummy hash, so that "user not found" takes the
+// same time as "user found, wrong password". This closes a timing side
+// channel that Compare leaves open by returning instantly on ErrHashEmpty.
+//
+// Existence is checked via DefaultRegistry rather than bcrypt's Parse, so a
+// hash produced by any registered backend (bcrypt, bcrypt-sha256, argon2id,
+// ...) is recognized as belonging to a real user; this matters for services
+// migrating users off bcrypt via Registry/Verify/NeedsRehash, where
+// CompareOrDummy must not treat an already-migrated hash as "doesn't exist".
+//
+// The dummy hash is generated lazily, once per process, at dummyCost; see
+// SetDummyHash to preload one instead.
+//
+// Parameters:
+//   - hash: The hash to compare against, or empty/unrecognized if the user
+//     does not exist.
+//   - password: The plaintext password to verify.
+//   - dummyCost: The cost to generate the dummy hash at on first use; should
+//     match the cost used for real hashes so timing is indistinguishable.
+//
+// Returns:
+//   - error: ErrCompareFailed if the user does not exist or the password is
+//     wrong; nil only if hash is recognized and matches password.
+func CompareOrDummy(hash, password []byte, dummyCost int) error {
+	h, err := DefaultRegistry.HasherFor(hash)
+	if err != nil {
+		_ = Compare(getDummyHash(dummyCost), password)
+		return ErrCompareFailed
+	}
+	return h.Compare(hash, password)
+}
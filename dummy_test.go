@@ -0,0 +1,45 @@
+package gobcrypt
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompareOrDummy(t *testing.T) {
+	password := []byte("password123")
+	hash, err := Generate(password, MinCost)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	t.Run("ExistingUserMatch", func(t *testing.T) {
+		if err := CompareOrDummy(hash, password, MinCost); err != nil {
+			t.Errorf("expected match, got %v", err)
+		}
+	})
+
+	t.Run("ExistingUserWrongPassword", func(t *testing.T) {
+		if err := CompareOrDummy(hash, []byte("wrongpassword"), MinCost); err == nil {
+			t.Error("expected error for wrong password, got nil")
+		}
+	})
+
+	t.Run("MissingUser", func(t *testing.T) {
+		if err := CompareOrDummy(nil, password, MinCost); err == nil {
+			t.Error("expected error for missing user, got nil")
+		}
+	})
+}
+
+func TestSetDummyHash(t *testing.T) {
+	preloaded, err := Generate([]byte("preloaded-dummy-password"), MinCost)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	SetDummyHash(preloaded)
+
+	if got := getDummyHash(MinCost); !bytes.Equal(got, preloaded) {
+		t.Errorf("getDummyHash() = %s, want preloaded hash %s", got, preloaded)
+	}
+}
@@ -0,0 +1,58 @@
+package gobcrypt
+
+// Hasher is implemented by every password hashing backend this package
+// supports. It mirrors the shape of the package-level Generate/Compare/
+// Cost/NeedsRehash functions so callers can swap bcrypt for argon2id or
+// scrypt without changing call sites. The package-level functions remain
+// bcrypt-only wrappers for back-compat; Hasher is the extension point for
+// everything else. To dispatch across several backends by the PHC-style
+// prefix of the stored hash, use a Registry with Default set, rather than
+// implementing your own switch over prefixes.
+type Hasher interface {
+	// Generate hashes password and returns an encoded hash in the backend's
+	// own format.
+	Generate(password []byte) ([]byte, error)
+
+	// Compare reports whether password matches the encoded hash.
+	Compare(hash, password []byte) error
+
+	// Cost extracts the primary work-factor parameter encoded in hash.
+	Cost(hash []byte) (int, error)
+
+	// NeedsRehash reports whether hash was produced with weaker parameters
+	// than this Hasher is currently configured to use.
+	NeedsRehash(hash []byte) bool
+}
+
+// BcryptHasher adapts the package-level bcrypt functions to the Hasher
+// interface.
+type BcryptHasher struct {
+	// TargetCost is the cost new hashes are generated with, and the minimum cost
+	// NeedsRehash accepts without flagging a hash for upgrade.
+	TargetCost int
+}
+
+// NewBcryptHasher returns a BcryptHasher that generates hashes at cost.
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{TargetCost: cost}
+}
+
+// Generate implements Hasher.
+func (h *BcryptHasher) Generate(password []byte) ([]byte, error) {
+	return Generate(password, h.TargetCost)
+}
+
+// Compare implements Hasher.
+func (h *BcryptHasher) Compare(hash, password []byte) error {
+	return Compare(hash, password)
+}
+
+// Cost implements Hasher.
+func (h *BcryptHasher) Cost(hash []byte) (int, error) {
+	return Cost(hash)
+}
+
+// NeedsRehash implements Hasher.
+func (h *BcryptHasher) NeedsRehash(hash []byte) bool {
+	return NeedsRehash(hash, h.TargetCost)
+}
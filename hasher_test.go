@@ -0,0 +1,94 @@
+package gobcrypt
+
+import "testing"
+
+func TestBcryptHasher(t *testing.T) {
+	h := NewBcryptHasher(MinCost)
+	password := []byte("password123")
+
+	hash, err := h.Generate(password)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if err := h.Compare(hash, password); err != nil {
+		t.Errorf("expected match, got %v", err)
+	}
+	if h.NeedsRehash(hash) {
+		t.Error("expected freshly generated hash not to need rehash")
+	}
+}
+
+func TestArgon2idHasher(t *testing.T) {
+	h := NewArgon2idHasher(1, 8*1024, 1)
+	password := []byte("password123")
+
+	hash, err := h.Generate(password)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if err := h.Compare(hash, password); err != nil {
+		t.Errorf("expected match, got %v", err)
+	}
+	if err := h.Compare(hash, []byte("wrongpassword")); err == nil {
+		t.Error("expected error for wrong password, got nil")
+	}
+
+	stronger := NewArgon2idHasher(2, 8*1024, 1)
+	if !stronger.NeedsRehash(hash) {
+		t.Error("expected hash generated with fewer iterations to need rehash")
+	}
+}
+
+func TestScryptHasher(t *testing.T) {
+	h := NewScryptHasher(1<<10, 8, 1)
+	password := []byte("password123")
+
+	hash, err := h.Generate(password)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if err := h.Compare(hash, password); err != nil {
+		t.Errorf("expected match, got %v", err)
+	}
+	if err := h.Compare(hash, []byte("wrongpassword")); err == nil {
+		t.Error("expected error for wrong password, got nil")
+	}
+
+	stronger := NewScryptHasher(1<<11, 8, 1)
+	if !stronger.NeedsRehash(hash) {
+		t.Error("expected hash generated with a smaller N to need rehash")
+	}
+}
+
+func TestRegistryAsHasher(t *testing.T) {
+	bcryptH := NewBcryptHasher(MinCost)
+	argon2H := NewArgon2idHasher(1, 8*1024, 1)
+	r := NewRegistry()
+	r.Default = argon2H
+	r.Register("$2a$", bcryptH)
+	r.Register("$argon2id$", argon2H)
+
+	password := []byte("password123")
+
+	legacyHash, err := bcryptH.Generate(password)
+	if err != nil {
+		t.Fatalf("bcrypt Generate failed: %v", err)
+	}
+	newHash, err := r.Generate(password)
+	if err != nil {
+		t.Fatalf("Registry Generate failed: %v", err)
+	}
+
+	if err := r.Compare(legacyHash, password); err != nil {
+		t.Errorf("expected Registry to verify legacy bcrypt hash, got %v", err)
+	}
+	if err := r.Compare(newHash, password); err != nil {
+		t.Errorf("expected Registry to verify its own argon2id hash, got %v", err)
+	}
+	if !r.NeedsRehash(legacyHash) {
+		t.Error("expected legacy bcrypt hash to need rehash onto the argon2id default")
+	}
+	if r.NeedsRehash(newHash) {
+		t.Error("expected freshly generated default hash not to need rehash")
+	}
+}
@@ -0,0 +1,140 @@
+package gobcrypt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptAlphabet is the custom base64 alphabet bcrypt uses to encode salts
+// and digests. It uses "./" where standard base64 uses "+/", and places
+// them first rather than last, so every character maps to a different
+// value than in standard base64.
+const bcryptAlphabet = "./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// bcryptEncoding is the unpadded codec for bcryptAlphabet.
+var bcryptEncoding = base64.NewEncoding(bcryptAlphabet).WithPadding(base64.NoPadding)
+
+// Hash is the structured form of a bcrypt hash string, as produced by
+// Generate and accepted by Compare. It also doubles as the round-trip type
+// for the full PHC/modular-crypt string format: String() re-encodes a
+// *Hash back into the same $version$cost$salt+digest layout Parse decoded
+// it from, rather than introducing a second, differently-named struct and
+// a second Parse for what is the same bcrypt hash shape. Unrecognized
+// prefixes are reported via the existing ErrInvalidHash, not a separate
+// error, for the same reason.
+type Hash struct {
+	// Version is the bcrypt format identifier: "2a", "2b", or "2y".
+	Version string
+	// Cost is the hashing cost the hash was generated with.
+	Cost int
+	// Salt is the decoded 16-byte salt.
+	Salt []byte
+	// Digest is the decoded 23-byte hash output.
+	Digest []byte
+}
+
+// String re-encodes h into the $version$cost$salt+digest bcrypt hash
+// format, such that Parse(h.String()) reproduces h for any *Hash returned
+// by Parse. It panics if Salt or Digest is not exactly 16 or 23 bytes,
+// since that can only happen if h was built by hand rather than via Parse.
+func (h *Hash) String() string {
+	if len(h.Salt) != 16 {
+		panic(fmt.Sprintf("gobcrypt: Hash.Salt must be 16 bytes, got %d", len(h.Salt)))
+	}
+	if len(h.Digest) != 23 {
+		panic(fmt.Sprintf("gobcrypt: Hash.Digest must be 23 bytes, got %d", len(h.Digest)))
+	}
+	return fmt.Sprintf("$%s$%02d$%s%s", h.Version, h.Cost,
+		bcryptEncoding.EncodeToString(h.Salt), bcryptEncoding.EncodeToString(h.Digest))
+}
+
+// Parse decodes hash into its constituent version, cost, salt, and digest,
+// mirroring the $version$cost$salt+digest layout x/crypto/bcrypt uses
+// internally.
+//
+// Parameters:
+//   - hash: The bcrypt hash to decode.
+//
+// Returns:
+//   - *Hash: The decoded fields.
+//   - error: ErrInvalidHash if hash is not a well-formed bcrypt hash.
+func Parse(hash []byte) (*Hash, error) {
+	if len(hash) < 60 || hash[0] != '$' {
+		return nil, ErrInvalidHash
+	}
+
+	parts := bytes.SplitN(hash[1:], []byte{'$'}, 3)
+	if len(parts) != 3 {
+		return nil, ErrInvalidHash
+	}
+
+	version := string(parts[0])
+	switch version {
+	case "2a", "2b", "2y":
+	default:
+		return nil, fmt.Errorf("%w: unknown version %q", ErrInvalidHash, version)
+	}
+
+	var cost int
+	if _, err := fmt.Sscanf(string(parts[1]), "%02d", &cost); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+
+	// 22 characters of salt followed by 31 characters of digest.
+	rest := parts[2]
+	if len(rest) != 53 {
+		return nil, ErrInvalidHash
+	}
+	salt, err := bcryptEncoding.DecodeString(string(rest[:22]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid salt: %v", ErrInvalidHash, err)
+	}
+	digest, err := bcryptEncoding.DecodeString(string(rest[22:]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid digest: %v", ErrInvalidHash, err)
+	}
+
+	return &Hash{Version: version, Cost: cost, Salt: salt, Digest: digest}, nil
+}
+
+// Info summarizes a parsed hash for migration tooling, e.g. bulk-scanning a
+// user table to decide which hashes to target for a rehash.
+type Info struct {
+	// Version is the bcrypt format identifier: "2a", "2b", or "2y".
+	Version string
+	// Cost is the hashing cost the hash was generated with.
+	Cost int
+	// Prehashed reports whether hash was produced via this library's
+	// pre-hashing pipeline (Prehash, used internally by Generate for
+	// passwords over PasswordLimit). Only set when Inspect is given a
+	// password to probe with; otherwise always false.
+	Prehashed bool
+}
+
+// Inspect parses hash and, if password is non-nil, probes whether the hash
+// was produced via this package's pre-hashing pipeline (Generate's
+// Prehash step for passwords over PasswordLimit) rather than a plain
+// bcrypt hash of password.
+//
+// Parameters:
+//   - hash: The bcrypt hash to inspect.
+//   - password: The plaintext to probe with, or nil to skip the probe.
+//
+// Returns:
+//   - Info: The version, cost, and (if probed) pre-hashing status.
+//   - error: An error if hash cannot be parsed.
+func Inspect(hash, password []byte) (Info, error) {
+	h, err := Parse(hash)
+	if err != nil {
+		return Info{}, err
+	}
+
+	info := Info{Version: h.Version, Cost: h.Cost}
+	if password != nil {
+		info.Prehashed = bcrypt.CompareHashAndPassword(hash, Prehash(password)) == nil
+	}
+	return info, nil
+}
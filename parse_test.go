@@ -0,0 +1,154 @@
+package gobcrypt
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	password := []byte("password123")
+	cost := 13
+	hash, err := Generate(password, cost)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	h, err := Parse(hash)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if h.Version != "2a" {
+		t.Errorf("expected version 2a, got %s", h.Version)
+	}
+	if h.Cost != cost {
+		t.Errorf("expected cost %d, got %d", cost, h.Cost)
+	}
+	if len(h.Salt) != 16 {
+		t.Errorf("expected 16-byte salt, got %d bytes", len(h.Salt))
+	}
+	if len(h.Digest) != 23 {
+		t.Errorf("expected 23-byte digest, got %d bytes", len(h.Digest))
+	}
+
+	t.Run("InvalidHash", func(t *testing.T) {
+		if _, err := Parse([]byte("not-a-bcrypt-hash")); err == nil {
+			t.Error("expected error for malformed hash, got nil")
+		}
+	})
+}
+
+func TestInspect(t *testing.T) {
+	t.Run("NotPrehashed", func(t *testing.T) {
+		password := []byte("password123")
+		hash, err := Generate(password, MinCost)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		info, err := Inspect(hash, password)
+		if err != nil {
+			t.Fatalf("Inspect failed: %v", err)
+		}
+		if info.Prehashed {
+			t.Error("expected Prehashed false for a hash of a short password")
+		}
+		if info.Cost != MinCost {
+			t.Errorf("expected cost %d, got %d", MinCost, info.Cost)
+		}
+	})
+
+	t.Run("Prehashed", func(t *testing.T) {
+		long := bytes.Repeat([]byte("a"), PasswordLimit+1)
+		hash, err := Generate(long, MinCost)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		info, err := Inspect(hash, long)
+		if err != nil {
+			t.Fatalf("Inspect failed: %v", err)
+		}
+		if !info.Prehashed {
+			t.Error("expected Prehashed true for a hash of a password over PasswordLimit")
+		}
+	})
+
+	t.Run("WithoutPassword", func(t *testing.T) {
+		hash, err := Generate([]byte("password123"), MinCost)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		info, err := Inspect(hash, nil)
+		if err != nil {
+			t.Fatalf("Inspect failed: %v", err)
+		}
+		if info.Prehashed {
+			t.Error("expected Prehashed false when no password is given to probe with")
+		}
+	})
+}
+
+func TestHashStringRoundTrip(t *testing.T) {
+	passwords := [][]byte{
+		[]byte(""),
+		[]byte("password123"),
+		bytes.Repeat([]byte("a"), PasswordLimit+1),
+	}
+
+	for _, password := range passwords {
+		for cost := MinCost; cost <= MinCost+2; cost++ {
+			hash, err := Generate(password, cost)
+			if err != nil {
+				t.Fatalf("Generate failed: %v", err)
+			}
+
+			h, err := Parse(hash)
+			if err != nil {
+				t.Fatalf("Parse(%s) failed: %v", hash, err)
+			}
+
+			if got := h.String(); got != string(hash) {
+				t.Errorf("Parse(%s).String() = %s, want %s", hash, got, hash)
+			}
+
+			// Parsing the round-tripped string must reproduce the same
+			// fields, not just the same string.
+			h2, err := Parse([]byte(h.String()))
+			if err != nil {
+				t.Fatalf("Parse(h.String()) failed: %v", err)
+			}
+			if h2.Version != h.Version || h2.Cost != h.Cost ||
+				!bytes.Equal(h2.Salt, h.Salt) || !bytes.Equal(h2.Digest, h.Digest) {
+				t.Errorf("round-tripped hash fields changed: %+v != %+v", h2, h)
+			}
+		}
+	}
+}
+
+// FuzzHashRoundTrip generates hashes from fuzzer-supplied passwords and costs
+// and checks that Parse(h).String() == h holds for all of them, exploring
+// edge cases (empty passwords, unusual lengths, boundary costs) beyond the
+// fixed cases TestHashStringRoundTrip covers.
+func FuzzHashRoundTrip(f *testing.F) {
+	f.Add([]byte(""), MinCost)
+	f.Add([]byte("password123"), MinCost)
+	f.Add(bytes.Repeat([]byte("a"), PasswordLimit+1), MinCost+2)
+
+	f.Fuzz(func(t *testing.T, password []byte, cost int) {
+		if cost < MinCost || cost > MinCost+2 {
+			t.Skip()
+		}
+
+		hash, err := Generate(password, cost)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+
+		h, err := Parse(hash)
+		if err != nil {
+			t.Fatalf("Parse(%s) failed: %v", hash, err)
+		}
+
+		if got := h.String(); got != string(hash) {
+			t.Errorf("Parse(%s).String() = %s, want %s", hash, got, hash)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package gobcrypt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// Pepper is a server-side secret mixed into every password via HMAC-SHA256
+// before bcrypt hashing. Unlike a salt, a pepper is never stored alongside
+// the hash (typically it lives in application config or a secrets
+// manager), so a leaked hash table alone is not enough to mount an offline
+// dictionary attack.
+type Pepper struct {
+	// ID identifies which pepper produced a given hash. Because the bcrypt
+	// hash format has no metadata slot, callers must record this themselves
+	// (e.g. in a column alongside the hash) to support rotation.
+	ID string
+
+	// Key is the HMAC-SHA256 key. It should come from config or a secrets
+	// manager, never from the same store as the hashes it peppers.
+	Key []byte
+}
+
+// pepperPrehash HMACs password with pepper.Key and base64-encodes the MAC,
+// producing a fixed-length input suitable for bcrypt in place of the plain
+// SHA-256 pre-hash used by PreHashPassword.
+func pepperPrehash(pepper Pepper, password []byte) []byte {
+	mac := hmac.New(sha256.New, pepper.Key)
+	mac.Write(password)
+	sum := mac.Sum(nil)
+	encoded := make([]byte, stdEncodedLen)
+	base64.StdEncoding.Encode(encoded, sum)
+	return encoded
+}
+
+// GenerateWithPepper hashes password the same way Generate does, except the
+// pre-hash step is HMAC-SHA256(pepper.Key, password) instead of plain
+// SHA-256. This is applied unconditionally, unlike Generate's pre-hash
+// (which only kicks in above PasswordLimit), since the whole point of a
+// pepper is to protect every password, not just long ones.
+//
+// The resulting hash is a plain bcrypt hash; it carries no indication that
+// a pepper was used. Callers must track that out of band (see
+// NeedsPepperRotation) and must use CompareWithPepper, not Compare, to
+// verify it.
+//
+// Parameters:
+//   - password: The plaintext password to hash.
+//   - pepper: The server-side secret to mix in.
+//   - cost: The cost factor for the bcrypt algorithm.
+//
+// Returns:
+//   - []byte: The generated bcrypt hash.
+//   - error: An error if the cost is invalid or generation fails.
+func GenerateWithPepper(password []byte, pepper Pepper, cost int) ([]byte, error) {
+	return Generate(pepperPrehash(pepper, password), cost)
+}
+
+// CompareWithPepper verifies a hash produced by GenerateWithPepper, trying
+// each pepper in peppers in order until one matches. This supports pepper
+// rotation: pass the current pepper first and any retired ones after it so
+// hashes peppered under an old key keep verifying.
+//
+// Parameters:
+//   - hash: The bcrypt hash to compare against.
+//   - password: The plaintext password to verify.
+//   - peppers: The peppers to try, in order.
+//
+// Returns:
+//   - error: nil if password matches hash under any pepper, otherwise the
+//     error from the last attempt (ErrHashEmpty if hash is empty,
+//     ErrCompareFailed if peppers is empty or none match).
+func CompareWithPepper(hash, password []byte, peppers []Pepper) error {
+	if len(hash) == 0 {
+		return ErrHashEmpty
+	}
+	if len(peppers) == 0 {
+		return fmt.Errorf("%w: no peppers provided", ErrCompareFailed)
+	}
+
+	var err error
+	for _, pepper := range peppers {
+		if err = Compare(hash, pepperPrehash(pepper, password)); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// NeedsPepperRotation reports whether a hash peppered under storedPepperID
+// should be re-peppered and rehashed with currentPepperID. Because the
+// bcrypt hash format itself carries no metadata, storedPepperID must come
+// from whatever out-of-band record the caller already keeps to know which
+// pepper produced a given hash (see Pepper.ID).
+func NeedsPepperRotation(storedPepperID, currentPepperID string) bool {
+	return storedPepperID != currentPepperID
+}
@@ -0,0 +1,56 @@
+package gobcrypt
+
+import "testing"
+
+func TestGenerateWithPepperAndCompare(t *testing.T) {
+	password := []byte("password123")
+	pepper := Pepper{ID: "v1", Key: []byte("super-secret-pepper-key")}
+
+	hash, err := GenerateWithPepper(password, pepper, MinCost)
+	if err != nil {
+		t.Fatalf("GenerateWithPepper failed: %v", err)
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		if err := CompareWithPepper(hash, password, []Pepper{pepper}); err != nil {
+			t.Errorf("expected match, got %v", err)
+		}
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		if err := CompareWithPepper(hash, []byte("wrongpassword"), []Pepper{pepper}); err == nil {
+			t.Error("expected error for wrong password, got nil")
+		}
+	})
+
+	t.Run("RotatedPepper", func(t *testing.T) {
+		newPepper := Pepper{ID: "v2", Key: []byte("new-secret-pepper-key")}
+		peppers := []Pepper{newPepper, pepper}
+		if err := CompareWithPepper(hash, password, peppers); err != nil {
+			t.Errorf("expected match via retired pepper, got %v", err)
+		}
+	})
+
+	t.Run("NoPeppersProvided", func(t *testing.T) {
+		if err := CompareWithPepper(hash, password, nil); err == nil {
+			t.Error("expected error when no peppers provided, got nil")
+		}
+	})
+
+	t.Run("WithoutPepperDoesNotMatch", func(t *testing.T) {
+		// A hash peppered with a secret key must not verify against the
+		// plain (non-peppered) password via the ordinary Compare.
+		if err := Compare(hash, password); err == nil {
+			t.Error("expected peppered hash not to verify with plain Compare")
+		}
+	})
+}
+
+func TestNeedsPepperRotation(t *testing.T) {
+	if NeedsPepperRotation("v1", "v1") {
+		t.Error("expected no rotation needed when pepper IDs match")
+	}
+	if !NeedsPepperRotation("v1", "v2") {
+		t.Error("expected rotation needed when pepper IDs differ")
+	}
+}
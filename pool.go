@@ -0,0 +1,149 @@
+package gobcrypt
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Pool runs Generate and Compare calls on a fixed-size set of worker
+// goroutines instead of directly on the caller's goroutine. bcrypt at cost
+// 12+ can pin a CPU for hundreds of milliseconds, so calling it straight
+// from request handlers lets a login stampede exhaust goroutines and
+// memory; Pool bounds the concurrency and sheds load instead.
+type Pool struct {
+	jobs chan poolJob
+	wg   sync.WaitGroup
+
+	queued         int64
+	inFlight       int64
+	rejected       int64
+	completed      int64
+	totalLatencyNs int64
+}
+
+type poolJob struct {
+	run    func() (any, error)
+	result chan poolResult
+}
+
+type poolResult struct {
+	value any
+	err   error
+}
+
+// NewPool starts workers goroutines draining a queue of size queueSize and
+// returns the resulting Pool. Call Close to stop the workers once the pool
+// is no longer needed.
+func NewPool(workers, queueSize int) *Pool {
+	p := &Pool{jobs: make(chan poolJob, queueSize)}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+	for j := range p.jobs {
+		atomic.AddInt64(&p.queued, -1)
+		atomic.AddInt64(&p.inFlight, 1)
+
+		start := time.Now()
+		value, err := j.run()
+		elapsed := time.Since(start)
+
+		atomic.AddInt64(&p.inFlight, -1)
+		atomic.AddInt64(&p.completed, 1)
+		atomic.AddInt64(&p.totalLatencyNs, int64(elapsed))
+		j.result <- poolResult{value: value, err: err}
+	}
+}
+
+// submit enqueues run, returning ErrPoolBusy immediately if the queue is
+// full, then waits for either its result or ctx to be done.
+func (p *Pool) submit(ctx context.Context, run func() (any, error)) (any, error) {
+	j := poolJob{run: run, result: make(chan poolResult, 1)}
+
+	// Count the job as queued before sending it, so a worker that picks it
+	// up immediately never decrements queued below zero.
+	atomic.AddInt64(&p.queued, 1)
+	select {
+	case p.jobs <- j:
+	default:
+		atomic.AddInt64(&p.queued, -1)
+		atomic.AddInt64(&p.rejected, 1)
+		return nil, ErrPoolBusy
+	}
+
+	select {
+	case r := <-j.result:
+		return r.value, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Generate submits a Generate(password, cost) call to the pool.
+//
+// Returns:
+//   - []byte: The generated bcrypt hash.
+//   - error: ErrPoolBusy if the queue is full, ctx.Err() if ctx is done
+//     before a worker finishes, or whatever error Generate itself returns.
+func (p *Pool) Generate(ctx context.Context, password []byte, cost int) ([]byte, error) {
+	v, err := p.submit(ctx, func() (any, error) {
+		return Generate(password, cost)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]byte), nil
+}
+
+// Compare submits a Compare(hash, password) call to the pool, with the same
+// backpressure and cancellation semantics as Generate.
+func (p *Pool) Compare(ctx context.Context, hash, password []byte) error {
+	_, err := p.submit(ctx, func() (any, error) {
+		return nil, Compare(hash, password)
+	})
+	return err
+}
+
+// Stats is a point-in-time snapshot of a Pool's load, suitable for
+// exposing as Prometheus-style gauges/counters.
+type Stats struct {
+	// Queued is the number of jobs waiting for a free worker.
+	Queued int
+	// InFlight is the number of jobs currently running.
+	InFlight int
+	// Rejected is the total number of jobs turned away with ErrPoolBusy
+	// since the pool was created.
+	Rejected int64
+	// MeanLatency is the average time completed jobs have spent running
+	// (not counting time spent queued), over the pool's lifetime.
+	MeanLatency time.Duration
+}
+
+// Stats returns a snapshot of the pool's current load.
+func (p *Pool) Stats() Stats {
+	completed := atomic.LoadInt64(&p.completed)
+	var mean time.Duration
+	if completed > 0 {
+		mean = time.Duration(atomic.LoadInt64(&p.totalLatencyNs) / completed)
+	}
+	return Stats{
+		Queued:      int(atomic.LoadInt64(&p.queued)),
+		InFlight:    int(atomic.LoadInt64(&p.inFlight)),
+		Rejected:    atomic.LoadInt64(&p.rejected),
+		MeanLatency: mean,
+	}
+}
+
+// Close stops accepting new work and waits for in-flight and already-queued
+// jobs to finish. It must not be called concurrently with Generate/Compare.
+func (p *Pool) Close() {
+	close(p.jobs)
+	p.wg.Wait()
+}
@@ -0,0 +1,131 @@
+package gobcrypt
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPoolGenerateAndCompare(t *testing.T) {
+	p := NewPool(2, 4)
+	defer p.Close()
+
+	ctx := context.Background()
+	password := []byte("password123")
+
+	hash, err := p.Generate(ctx, password, MinCost)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if err := p.Compare(ctx, hash, password); err != nil {
+		t.Errorf("expected match, got %v", err)
+	}
+	if err := p.Compare(ctx, hash, []byte("wrongpassword")); err == nil {
+		t.Error("expected error for wrong password, got nil")
+	}
+}
+
+func TestPoolBackpressure(t *testing.T) {
+	// One worker and a one-slot queue: the worker is occupied by the first
+	// submission and the second fills the queue, so a third concurrent
+	// submission must see the queue as full and be rejected.
+	p := NewPool(1, 1)
+	defer p.Close()
+
+	ctx := context.Background()
+	block := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.submit(ctx, func() (any, error) {
+			<-block
+			return nil, nil
+		})
+	}()
+
+	// Give the worker goroutine time to pick up the blocking job.
+	time.Sleep(50 * time.Millisecond)
+
+	// Fill the single queue slot.
+	var wg2 sync.WaitGroup
+	wg2.Add(1)
+	go func() {
+		defer wg2.Done()
+		p.submit(ctx, func() (any, error) {
+			<-block
+			return nil, nil
+		})
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	_, err := p.Generate(ctx, []byte("password123"), MinCost)
+	if err != ErrPoolBusy {
+		t.Errorf("expected ErrPoolBusy, got %v", err)
+	}
+
+	close(block)
+	wg.Wait()
+	wg2.Wait()
+}
+
+func TestPoolCancellation(t *testing.T) {
+	p := NewPool(0, 1)
+	defer p.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// No workers are running, so the job sits queued until the context
+	// expires.
+	_, err := p.Generate(ctx, []byte("password123"), MinCost)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestPoolStats(t *testing.T) {
+	p := NewPool(2, 4)
+	defer p.Close()
+
+	ctx := context.Background()
+	if _, err := p.Generate(ctx, []byte("password123"), MinCost); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	stats := p.Stats()
+	if stats.Rejected != 0 {
+		t.Errorf("expected 0 rejected, got %d", stats.Rejected)
+	}
+	if stats.MeanLatency <= 0 {
+		t.Error("expected a positive mean latency after at least one completed job")
+	}
+}
+
+func TestPoolFairness(t *testing.T) {
+	// Several concurrent submissions to a pool with enough capacity should
+	// all eventually complete, in any order, none starved.
+	p := NewPool(4, 16)
+	defer p.Close()
+
+	ctx := context.Background()
+	const n = 8
+	var wg sync.WaitGroup
+	wg.Add(n)
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = p.Generate(ctx, []byte("password123"), MinCost)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("job %d failed: %v", i, err)
+		}
+	}
+}
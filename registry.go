@@ -0,0 +1,124 @@
+package gobcrypt
+
+import "bytes"
+
+// Registry maps hash format prefixes to the Hasher responsible for them, so
+// callers can plug in custom schemes beyond the ones this package ships. A
+// Registry with Default set is itself a Hasher: Generate delegates to
+// Default, and Compare/Cost/NeedsRehash dispatch to whichever registered
+// backend produced the hash, so a service can migrate from one scheme to
+// another incrementally (see Verify and NeedsRehash below).
+type Registry struct {
+	// Default is the Hasher Generate delegates to, and the backend
+	// NeedsRehash requires a hash to already match before deferring to that
+	// backend's own NeedsRehash. Leave nil if the Registry is only used for
+	// Verify-style dispatch.
+	Default Hasher
+
+	entries []registryEntry
+}
+
+type registryEntry struct {
+	prefix []byte
+	hasher Hasher
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register associates prefix (e.g. "$argon2id$") with hasher. Entries are
+// matched in registration order, so register more specific prefixes before
+// more general ones that could also match.
+func (r *Registry) Register(prefix string, hasher Hasher) {
+	r.entries = append(r.entries, registryEntry{prefix: []byte(prefix), hasher: hasher})
+}
+
+// HasherFor returns the Hasher registered for hash's prefix.
+//
+// Returns:
+//   - Hasher: The matching backend.
+//   - error: ErrUnknownHashVariant if no registered prefix matches hash.
+func (r *Registry) HasherFor(hash []byte) (Hasher, error) {
+	for _, e := range r.entries {
+		if bytes.HasPrefix(hash, e.prefix) {
+			return e.hasher, nil
+		}
+	}
+	return nil, ErrUnknownHashVariant
+}
+
+// Generate implements Hasher by delegating to Default.
+func (r *Registry) Generate(password []byte) ([]byte, error) {
+	return r.Default.Generate(password)
+}
+
+// Compare implements Hasher by dispatching to the backend that produced hash.
+func (r *Registry) Compare(hash, password []byte) error {
+	h, err := r.HasherFor(hash)
+	if err != nil {
+		return err
+	}
+	return h.Compare(hash, password)
+}
+
+// Cost implements Hasher by dispatching to the backend that produced hash.
+func (r *Registry) Cost(hash []byte) (int, error) {
+	h, err := r.HasherFor(hash)
+	if err != nil {
+		return 0, err
+	}
+	return h.Cost(hash)
+}
+
+// NeedsRehash implements Hasher by dispatching to the backend that produced
+// hash. A hash in a format no registered backend recognizes, or one
+// produced by a backend other than Default, is always flagged for rehash.
+func (r *Registry) NeedsRehash(hash []byte) bool {
+	h, err := r.HasherFor(hash)
+	if err != nil {
+		return true
+	}
+	if h != r.Default {
+		return true
+	}
+	return h.NeedsRehash(hash)
+}
+
+// DefaultRegistry is the Registry Verify uses. It is pre-populated with
+// this package's own backends at DefaultCost / recommended parameters;
+// register additional schemes, or overwrite these entries with
+// differently-tuned Hashers, before calling Verify.
+var DefaultRegistry = func() *Registry {
+	r := NewRegistry()
+	r.Register("$2a$", NewBcryptHasher(DefaultCost))
+	r.Register("$2b$", NewBcryptHasher(DefaultCost))
+	r.Register("$2y$", NewBcryptHasher(DefaultCost))
+	r.Register(bcryptSHA256Prefix, NewBcryptSHA256Hasher(DefaultCost))
+	r.Register("$argon2id$", NewArgon2idHasher(0, 0, 0))
+	r.Register("$scrypt$", NewScryptHasher(0, 0, 0))
+	return r
+}()
+
+// Verify compares hash and password, dispatching to whichever backend in
+// DefaultRegistry matches hash's prefix. This lets a service migrate from
+// bcrypt to argon2id (or bcrypt-sha256) incrementally: verify old hashes
+// under their original backend, and when that backend's NeedsRehash
+// reports true, re-hash the plaintext under the new scheme at the caller's
+// next successful login.
+//
+// Parameters:
+//   - hash: The hash to compare against.
+//   - password: The plaintext password to verify.
+//
+// Returns:
+//   - error: nil on match, or an error from the matching backend's Compare
+//     (ErrUnknownHashVariant if hash's prefix is not registered).
+func Verify(hash, password []byte) error {
+	h, err := DefaultRegistry.HasherFor(hash)
+	if err != nil {
+		return err
+	}
+	return h.Compare(hash, password)
+}
@@ -0,0 +1,71 @@
+package gobcrypt
+
+import "testing"
+
+func TestBcryptSHA256Hasher(t *testing.T) {
+	h := NewBcryptSHA256Hasher(MinCost)
+	password := []byte("password123")
+
+	hash, err := h.Generate(password)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if err := h.Compare(hash, password); err != nil {
+		t.Errorf("expected match, got %v", err)
+	}
+	if err := h.Compare(hash, []byte("wrongpassword")); err == nil {
+		t.Error("expected error for wrong password, got nil")
+	}
+}
+
+func TestRegistryAndVerify(t *testing.T) {
+	r := NewRegistry()
+	bcryptH := NewBcryptHasher(MinCost)
+	argon2H := NewArgon2idHasher(1, 8*1024, 1)
+	r.Register("$2a$", bcryptH)
+	r.Register("$argon2id$", argon2H)
+
+	password := []byte("password123")
+	bcryptHash, err := bcryptH.Generate(password)
+	if err != nil {
+		t.Fatalf("bcrypt Generate failed: %v", err)
+	}
+	argonHash, err := argon2H.Generate(password)
+	if err != nil {
+		t.Fatalf("argon2 Generate failed: %v", err)
+	}
+
+	if h, err := r.HasherFor(bcryptHash); err != nil || h != Hasher(bcryptH) {
+		t.Errorf("expected bcrypt hash to resolve to bcryptH, got %v, %v", h, err)
+	}
+	if h, err := r.HasherFor(argonHash); err != nil || h != Hasher(argon2H) {
+		t.Errorf("expected argon2 hash to resolve to argon2H, got %v, %v", h, err)
+	}
+	if _, err := r.HasherFor([]byte("$unknown$foo")); err == nil {
+		t.Error("expected error for unregistered prefix, got nil")
+	}
+
+	t.Run("Verify", func(t *testing.T) {
+		hash, err := Generate(password, MinCost)
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if err := Verify(hash, password); err != nil {
+			t.Errorf("expected Verify to match a plain bcrypt hash, got %v", err)
+		}
+	})
+
+	t.Run("VerifyScrypt", func(t *testing.T) {
+		scryptH := NewScryptHasher(1<<10, 8, 1)
+		hash, err := scryptH.Generate(password)
+		if err != nil {
+			t.Fatalf("scrypt Generate failed: %v", err)
+		}
+		if err := Verify(hash, password); err != nil {
+			t.Errorf("expected Verify to match a scrypt hash via DefaultRegistry, got %v", err)
+		}
+		if err := CompareOrDummy(hash, password, MinCost); err != nil {
+			t.Errorf("expected CompareOrDummy to match a scrypt hash via DefaultRegistry, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package gobcrypt
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/blowfish"
+)
+
+// bcryptMagicCipherData is "OrpheanBeholderScryDoubt" in ASCII, the fixed
+// plaintext x/crypto/bcrypt (and every other bcrypt implementation)
+// encrypts 64 times per round to produce the 24-byte digest.
+var bcryptMagicCipherData = []byte{
+	0x4f, 0x72, 0x70, 0x68,
+	0x65, 0x61, 0x6e, 0x42,
+	0x65, 0x68, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x53,
+	0x63, 0x72, 0x79, 0x44,
+	0x6f, 0x75, 0x62, 0x74,
+}
+
+// GenerateWithSalt hashes password at cost using salt instead of a random
+// salt from crypto/rand, replicating bcrypt's own hash-assembly path (cost
+// byte, base64-encoded salt, 23-byte checksum) so the result is byte-for-
+// byte verifiable with Compare. x/crypto/bcrypt offers no public API for
+// supplying a salt, so this re-implements its Blowfish-based core directly.
+//
+// This exists for deterministic test vectors, KMS/HSM-driven salt
+// derivation (e.g. HKDF from a per-tenant secret), and cross-language
+// interop reproduction — none of which a crypto/rand-only API supports.
+//
+// Parameters:
+//   - password: The plaintext password to hash.
+//   - salt: Exactly 16 bytes of caller-supplied salt.
+//   - cost: The cost factor for the bcrypt algorithm.
+//
+// Returns:
+//   - []byte: The generated bcrypt hash, in the same $2a$ format as Generate.
+//   - error: ErrInvalidSaltLength if salt is not 16 bytes, or the same
+//     errors as Generate for an invalid cost.
+func GenerateWithSalt(password, salt []byte, cost int) ([]byte, error) {
+	if len(salt) != 16 {
+		return nil, fmt.Errorf("%w: got %d bytes", ErrInvalidSaltLength, len(salt))
+	}
+	if len(password) > PasswordLimit {
+		password = Prehash(password)
+	}
+	if cost < MinCost {
+		return nil, fmt.Errorf("%w: got %d", ErrCostTooLow, cost)
+	}
+	if cost > MaxCost {
+		return nil, fmt.Errorf("%w: got %d", ErrCostTooHigh, cost)
+	}
+
+	digest, err := bcryptHash(password, cost, salt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGenerateFailed, err)
+	}
+
+	encodedSalt := bcryptEncoding.EncodeToString(salt)
+	encodedDigest := bcryptEncoding.EncodeToString(digest)
+	return []byte(fmt.Sprintf("$2a$%02d$%s%s", cost, encodedSalt, encodedDigest)), nil
+}
+
+// bcryptHash runs bcrypt's Blowfish-based core over password, returning the
+// 23-byte digest (the first 23 bytes of the 24-byte Blowfish-ECB output;
+// the 24th byte is dropped for compatibility with C bcrypt
+// implementations).
+func bcryptHash(password []byte, cost int, salt []byte) ([]byte, error) {
+	// Bug-compatible with C bcrypt implementations: a trailing NUL is
+	// included in the key for the key-expansion rounds.
+	key := append(append([]byte{}, password...), 0)
+
+	c, err := blowfish.NewSaltedCipher(key, salt)
+	if err != nil {
+		return nil, err
+	}
+	rounds := uint64(1) << uint(cost)
+	for i := uint64(0); i < rounds; i++ {
+		blowfish.ExpandKey(key, c)
+		blowfish.ExpandKey(salt, c)
+	}
+
+	cipherData := append([]byte{}, bcryptMagicCipherData...)
+	for i := 0; i < 24; i += 8 {
+		for j := 0; j < 64; j++ {
+			c.Encrypt(cipherData[i:i+8], cipherData[i:i+8])
+		}
+	}
+	return cipherData[:23], nil
+}
@@ -0,0 +1,42 @@
+package gobcrypt
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGenerateWithSalt(t *testing.T) {
+	password := []byte("password123")
+	salt := bytes.Repeat([]byte{0x42}, 16)
+
+	t.Run("Deterministic", func(t *testing.T) {
+		h1, err := GenerateWithSalt(password, salt, MinCost)
+		if err != nil {
+			t.Fatalf("GenerateWithSalt failed: %v", err)
+		}
+		h2, err := GenerateWithSalt(password, salt, MinCost)
+		if err != nil {
+			t.Fatalf("GenerateWithSalt failed: %v", err)
+		}
+		if !bytes.Equal(h1, h2) {
+			t.Fatalf("expected identical hashes for identical salt, got %s != %s", h1, h2)
+		}
+	})
+
+	t.Run("VerifiesWithCompare", func(t *testing.T) {
+		hash, err := GenerateWithSalt(password, salt, MinCost)
+		if err != nil {
+			t.Fatalf("GenerateWithSalt failed: %v", err)
+		}
+		if err := Compare(hash, password); err != nil {
+			t.Errorf("expected hash to verify with Compare, got %v", err)
+		}
+	})
+
+	t.Run("InvalidSaltLength", func(t *testing.T) {
+		_, err := GenerateWithSalt(password, salt[:15], MinCost)
+		if err == nil {
+			t.Error("expected error for 15-byte salt, got nil")
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package gobcrypt
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// ScryptHasher hashes passwords with scrypt, encoding hashes as:
+// $scrypt$ln=<log2 N>,r=<r>,p=<p>$<salt>$<hash>
+type ScryptHasher struct {
+	// N is the CPU/memory cost parameter; must be a power of two.
+	N int
+	// R is the block size parameter.
+	R int
+	// P is the parallelization parameter.
+	P int
+	// SaltLen and KeyLen control the generated salt and digest lengths, in
+	// bytes.
+	SaltLen int
+	KeyLen  int
+}
+
+// NewScryptHasher returns a ScryptHasher with sensible defaults for any of
+// n, r, p left at zero (N=1<<15, r=8, p=1, the interactive-login parameters
+// golang.org/x/crypto/scrypt's documentation recommends), and a 16-byte
+// salt / 32-byte key.
+func NewScryptHasher(n, r, p int) *ScryptHasher {
+	if n == 0 {
+		n = 1 << 15
+	}
+	if r == 0 {
+		r = 8
+	}
+	if p == 0 {
+		p = 1
+	}
+	return &ScryptHasher{N: n, R: r, P: p, SaltLen: 16, KeyLen: 32}
+}
+
+// Generate implements Hasher.
+func (h *ScryptHasher) Generate(password []byte) ([]byte, error) {
+	salt := make([]byte, h.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGenerateFailed, err)
+	}
+	key, err := scrypt.Key(password, salt, h.N, h.R, h.P, h.KeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGenerateFailed, err)
+	}
+
+	encoded := fmt.Sprintf("$scrypt$ln=%d,r=%d,p=%d$%s$%s",
+		bits.Len(uint(h.N))-1, h.R, h.P,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key))
+	return []byte(encoded), nil
+}
+
+// Compare implements Hasher.
+func (h *ScryptHasher) Compare(hash, password []byte) error {
+	n, r, p, salt, key, err := parseScryptHash(hash)
+	if err != nil {
+		return err
+	}
+	candidate, err := scrypt.Key(password, salt, n, r, p, len(key))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrCompareFailed, err)
+	}
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		return ErrCompareFailed
+	}
+	return nil
+}
+
+// Cost implements Hasher, returning log2(N), scrypt's primary work-factor
+// parameter.
+func (h *ScryptHasher) Cost(hash []byte) (int, error) {
+	n, _, _, _, _, err := parseScryptHash(hash)
+	if err != nil {
+		return 0, err
+	}
+	return bits.Len(uint(n)) - 1, nil
+}
+
+// NeedsRehash implements Hasher, reporting true if hash used a weaker N, r,
+// or p than h is currently configured with.
+func (h *ScryptHasher) NeedsRehash(hash []byte) bool {
+	n, r, p, _, _, err := parseScryptHash(hash)
+	if err != nil {
+		return true
+	}
+	return n < h.N || r < h.R || p < h.P
+}
+
+// parseScryptHash decodes a $scrypt$ln=...,r=...,p=...$salt$hash string
+// into N, r, p, salt, and digest.
+func parseScryptHash(hash []byte) (n, r, p int, salt, key []byte, err error) {
+	parts := strings.Split(string(hash), "$")
+	// Splitting on '$' yields 5 parts: an empty string before the leading
+	// '$', then "scrypt", params, salt, and digest.
+	if len(parts) != 5 || parts[1] != "scrypt" {
+		return 0, 0, 0, nil, nil, ErrInvalidHash
+	}
+	var ln int
+	if _, err = fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &ln, &r, &p); err != nil {
+		return 0, 0, 0, nil, nil, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+	n = 1 << ln
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[3]); err != nil {
+		return 0, 0, 0, nil, nil, fmt.Errorf("%w: invalid salt: %v", ErrInvalidHash, err)
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return 0, 0, 0, nil, nil, fmt.Errorf("%w: invalid digest: %v", ErrInvalidHash, err)
+	}
+	return n, r, p, salt, key, nil
+}